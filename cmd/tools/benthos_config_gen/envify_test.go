@@ -0,0 +1,132 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import "testing"
+
+func TestPathMatchesPattern(t *testing.T) {
+	cases := []struct {
+		path, pattern string
+		expect        bool
+	}{
+		{"input.processors.0.type", "*.processors.*", true},
+		{"output.processors.1.json.path", "*.processors.*", true},
+		{"input.kafka.addresses.0", "*.processors.*", false},
+		{"input.read_until.check.type", "*.read_until.*", true},
+		{"output.read_until.check.type", "*.read_until.*", true},
+		{"input.kafka.topic", "*.read_until.*", false},
+	}
+	for _, c := range cases {
+		if act := pathMatchesPattern(c.path, c.pattern); act != c.expect {
+			t.Errorf("pathMatchesPattern(%q, %q) = %v, want %v", c.path, c.pattern, act, c.expect)
+		}
+	}
+}
+
+func TestEnvifyBlocked(t *testing.T) {
+	if !envifyBlocked("input.processors.0.type", "json") {
+		t.Error("expected a field under a processors subtree to be blocked")
+	}
+	if !envifyBlocked("input.read_until.check.type", "message.() == null") {
+		t.Error("expected a field under a read_until subtree to be blocked")
+	}
+	if !envifyBlocked("output.some_toggle", true) {
+		t.Error("expected boolean leaves to always be blocked")
+	}
+	if envifyBlocked("input.kafka.topic", "foo") {
+		t.Error("did not expect an ordinary string leaf to be blocked")
+	}
+}
+
+func TestEnvifyAllowListOverridesBlocking(t *testing.T) {
+	if !envifyBlocked("buffer.type", "memory") {
+		t.Error("expected a type field not on the allow-list to be blocked")
+	}
+	if envifyBlocked("input.type", "kafka") {
+		t.Error("input.type must always be envified via the allow-list")
+	}
+	if envifyBlocked("output.type", "kafka") {
+		t.Error("output.type must always be envified via the allow-list")
+	}
+}
+
+func TestEnvifyVarName(t *testing.T) {
+	if exp, act := "INPUT_KAFKA_ADDRESSES_0", envifyVarName("input.kafka.addresses.0"); exp != act {
+		t.Errorf("wrong var name: %v != %v", act, exp)
+	}
+}
+
+func TestEnvifyWalk(t *testing.T) {
+	sanit := map[string]interface{}{
+		"input": map[string]interface{}{
+			"type": "kafka",
+			"kafka": map[string]interface{}{
+				"topic": "foo",
+			},
+			"processors": []interface{}{
+				map[string]interface{}{"type": "json"},
+			},
+		},
+		"output": map[string]interface{}{
+			"type": "stdout",
+		},
+	}
+
+	envSanit, vars, err := envify(sanit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	varNames := map[string]string{}
+	for _, v := range vars {
+		varNames[v.Name] = v.Default
+	}
+
+	if _, ok := varNames["INPUT_TYPE"]; !ok {
+		t.Error("expected INPUT_TYPE to always be envified via the allow-list")
+	}
+	if _, ok := varNames["OUTPUT_TYPE"]; !ok {
+		t.Error("expected OUTPUT_TYPE to always be envified via the allow-list")
+	}
+	if _, ok := varNames["INPUT_KAFKA_TOPIC"]; !ok {
+		t.Error("expected a plain scalar leaf to be envified")
+	}
+
+	envMap, ok := envSanit.(map[string]interface{})
+	if !ok {
+		t.Fatalf("unexpected envified shape: %T", envSanit)
+	}
+	inputMap, ok := envMap["input"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("unexpected envified input shape: %T", envMap["input"])
+	}
+	procs, ok := inputMap["processors"].([]interface{})
+	if !ok || len(procs) != 1 {
+		t.Fatalf("unexpected envified processors shape: %T", inputMap["processors"])
+	}
+	firstProc, ok := procs[0].(map[string]interface{})
+	if !ok {
+		t.Fatalf("unexpected envified processor shape: %T", procs[0])
+	}
+	if exp, act := "json", firstProc["type"]; exp != act {
+		t.Errorf("expected the processors subtree to be left untouched, got %v", act)
+	}
+}
@@ -21,19 +21,28 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	_ "net/http/pprof"
+	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/Jeffail/benthos/lib/api"
 	"github.com/Jeffail/benthos/lib/buffer"
+	"github.com/Jeffail/benthos/lib/config/lint"
+	"github.com/Jeffail/benthos/lib/configservice"
 	"github.com/Jeffail/benthos/lib/input"
+	"github.com/Jeffail/benthos/lib/manager"
 	"github.com/Jeffail/benthos/lib/output"
 	"github.com/Jeffail/benthos/lib/pipeline"
 	"github.com/Jeffail/benthos/lib/processor"
+	"github.com/Jeffail/benthos/lib/stream"
 	yaml "gopkg.in/yaml.v2"
 )
 
@@ -41,59 +50,75 @@ import (
 
 // Config is the benthos configuration struct.
 type Config struct {
-	HTTP     api.Config      `json:"http" yaml:"http"`
-	Input    input.Config    `json:"input" yaml:"input"`
-	Buffer   buffer.Config   `json:"buffer" yaml:"buffer"`
-	Pipeline pipeline.Config `json:"pipeline" yaml:"pipeline"`
-	Output   output.Config   `json:"output" yaml:"output"`
+	HTTP          api.Config           `json:"http" yaml:"http"`
+	Input         input.Config         `json:"input" yaml:"input"`
+	Buffer        buffer.Config        `json:"buffer" yaml:"buffer"`
+	Pipeline      pipeline.Config      `json:"pipeline" yaml:"pipeline"`
+	Output        output.Config        `json:"output" yaml:"output"`
+	ConfigService configservice.Config `json:"config_service" yaml:"config_service"`
 }
 
 // NewConfig returns a new configuration with default values.
 func NewConfig() Config {
 	return Config{
-		HTTP:     api.NewConfig(),
-		Input:    input.NewConfig(),
-		Buffer:   buffer.NewConfig(),
-		Pipeline: pipeline.NewConfig(),
-		Output:   output.NewConfig(),
+		HTTP:          api.NewConfig(),
+		Input:         input.NewConfig(),
+		Buffer:        buffer.NewConfig(),
+		Pipeline:      pipeline.NewConfig(),
+		Output:        output.NewConfig(),
+		ConfigService: configservice.NewConfig(),
 	}
 }
 
-// Sanitised returns a sanitised copy of the Benthos configuration, meaning
-// fields of no consequence (unused inputs, outputs, processors etc) are
-// excluded.
-func (c Config) Sanitised() (interface{}, error) {
-	inConf, err := input.SanitiseConfig(c.Input)
+// streamConfig returns the portion of c that maps onto a stream.Config, i.e.
+// the fields shared with a named stream entry in a Manifest.
+func (c Config) streamConfig() stream.Config {
+	return stream.Config{
+		Input:    c.Input,
+		Buffer:   c.Buffer,
+		Pipeline: c.Pipeline,
+		Output:   c.Output,
+	}
+}
+
+// sanitisedStream is the sanitised shape shared by a single-stream Config
+// and a named entry within a streams Manifest.
+type sanitisedStream struct {
+	Input    interface{} `json:"input" yaml:"input"`
+	Buffer   interface{} `json:"buffer" yaml:"buffer"`
+	Pipeline interface{} `json:"pipeline" yaml:"pipeline"`
+	Output   interface{} `json:"output" yaml:"output"`
+}
+
+// sanitiseStreamConfig returns a sanitised copy of a single stream.Config,
+// meaning fields of no consequence (unused inputs, outputs, processors etc)
+// are excluded. This is the part of a Config or Manifest entry shared by
+// both the single-stream and streams-manifest generation modes.
+func sanitiseStreamConfig(conf stream.Config) (sanitisedStream, error) {
+	inConf, err := input.SanitiseConfig(conf.Input)
 	if err != nil {
-		return nil, err
+		return sanitisedStream{}, err
 	}
 
 	var bufConf interface{}
-	bufConf, err = buffer.SanitiseConfig(c.Buffer)
+	bufConf, err = buffer.SanitiseConfig(conf.Buffer)
 	if err != nil {
-		return nil, err
+		return sanitisedStream{}, err
 	}
 
 	var pipeConf interface{}
-	pipeConf, err = pipeline.SanitiseConfig(c.Pipeline)
+	pipeConf, err = pipeline.SanitiseConfig(conf.Pipeline)
 	if err != nil {
-		return nil, err
+		return sanitisedStream{}, err
 	}
 
 	var outConf interface{}
-	outConf, err = output.SanitiseConfig(c.Output)
+	outConf, err = output.SanitiseConfig(conf.Output)
 	if err != nil {
-		return nil, err
+		return sanitisedStream{}, err
 	}
 
-	return struct {
-		HTTP     interface{} `json:"http" yaml:"http"`
-		Input    interface{} `json:"input" yaml:"input"`
-		Buffer   interface{} `json:"buffer" yaml:"buffer"`
-		Pipeline interface{} `json:"pipeline" yaml:"pipeline"`
-		Output   interface{} `json:"output" yaml:"output"`
-	}{
-		HTTP:     c.HTTP,
+	return sanitisedStream{
 		Input:    inConf,
 		Buffer:   bufConf,
 		Pipeline: pipeConf,
@@ -101,6 +126,307 @@ func (c Config) Sanitised() (interface{}, error) {
 	}, nil
 }
 
+// Sanitised returns a sanitised copy of the Benthos configuration, meaning
+// fields of no consequence (unused inputs, outputs, processors etc) are
+// excluded.
+func (c Config) Sanitised() (interface{}, error) {
+	sanitStream, err := sanitiseStreamConfig(c.streamConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	return struct {
+		HTTP          interface{} `json:"http" yaml:"http"`
+		Input         interface{} `json:"input" yaml:"input"`
+		Buffer        interface{} `json:"buffer" yaml:"buffer"`
+		Pipeline      interface{} `json:"pipeline" yaml:"pipeline"`
+		Output        interface{} `json:"output" yaml:"output"`
+		ConfigService interface{} `json:"config_service" yaml:"config_service"`
+	}{
+		HTTP:          c.HTTP,
+		Input:         sanitStream.Input,
+		Buffer:        sanitStream.Buffer,
+		Pipeline:      sanitStream.Pipeline,
+		Output:        sanitStream.Output,
+		ConfigService: c.ConfigService,
+	}, nil
+}
+
+//------------------------------------------------------------------------------
+
+// Manifest is the top level document used in "streams" mode, allowing many
+// named stream configs to be generated and run under a single shared HTTP
+// API and resource manager.
+type Manifest struct {
+	HTTP    api.Config               `json:"http" yaml:"http"`
+	Manager manager.Config           `json:"manager" yaml:"manager"`
+	Streams map[string]stream.Config `json:"streams" yaml:"streams"`
+}
+
+// NewManifest returns a Manifest with default values and no streams.
+func NewManifest() Manifest {
+	return Manifest{
+		HTTP:    api.NewConfig(),
+		Manager: manager.NewConfig(),
+		Streams: map[string]stream.Config{},
+	}
+}
+
+// Sanitised returns a sanitised copy of the manifest, where each named
+// stream has been sanitised the same way as a single-stream Config.
+func (m Manifest) Sanitised() (interface{}, error) {
+	sanitStreams := make(map[string]sanitisedStream, len(m.Streams))
+	for id, sConf := range m.Streams {
+		sanit, err := sanitiseStreamConfig(sConf)
+		if err != nil {
+			return nil, fmt.Errorf("stream '%v': %v", id, err)
+		}
+		sanitStreams[id] = sanit
+	}
+
+	return struct {
+		HTTP    interface{} `json:"http" yaml:"http"`
+		Manager interface{} `json:"manager" yaml:"manager"`
+		Streams interface{} `json:"streams" yaml:"streams"`
+	}{
+		HTTP:    m.HTTP,
+		Manager: m.Manager,
+		Streams: sanitStreams,
+	}, nil
+}
+
+//------------------------------------------------------------------------------
+
+// envifyAllowList contains paths that should always be converted into env
+// var placeholders, even though they would otherwise be excluded by the
+// blocklist below (e.g. because they determine which fields are valid
+// siblings).
+var envifyAllowList = map[string]struct{}{
+	"input.type":  {},
+	"output.type": {},
+}
+
+// envifyBlockList contains path patterns that must never be converted into
+// env var placeholders, because substituting them would change the
+// effective type of the YAML node (and therefore break parsing) or would
+// alter which component is configured. A "*" segment matches any single
+// path element, and a pattern also matches every path that extends it, so
+// e.g. "*.processors.*" blocks whole processors subtrees, while "*.type"
+// blocks top-level fields like "buffer.type" but not a type field nested
+// further down a component's own config tree.
+var envifyBlockList = []string{
+	"*.type",
+	"*.processors.*",
+	"*.read_until.*",
+}
+
+// pathMatchesPattern reports whether the dot-separated path matches the
+// dot-separated pattern, where a "*" segment in the pattern matches any
+// single path segment. A path longer than the pattern matches when its
+// leading segments do.
+func pathMatchesPattern(path, pattern string) bool {
+	pathParts := strings.Split(path, ".")
+	patternParts := strings.Split(pattern, ".")
+	if len(patternParts) > len(pathParts) {
+		return false
+	}
+	for i, p := range patternParts {
+		if p == "*" {
+			continue
+		}
+		if p != pathParts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// envifyBlocked returns true if path should never be replaced with an env
+// var placeholder.
+func envifyBlocked(path string, leaf interface{}) bool {
+	if _, allowed := envifyAllowList[path]; allowed {
+		return false
+	}
+	if _, isBool := leaf.(bool); isBool {
+		return true
+	}
+	for _, pattern := range envifyBlockList {
+		if pathMatchesPattern(path, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// envifyVarName converts a dot/index separated JSON path, e.g.
+// "input.kafka.addresses.0", into an upper snake-case env var name, e.g.
+// "INPUT_KAFKA_ADDRESSES_0".
+func envifyVarName(path string) string {
+	parts := strings.Split(path, ".")
+	return strings.ToUpper(strings.Join(parts, "_"))
+}
+
+// envifyLeafValue renders a scalar leaf value as it should appear inside the
+// `${VAR:default}` placeholder and as the value written to the .env file.
+func envifyLeafValue(leaf interface{}) string {
+	switch t := leaf.(type) {
+	case string:
+		return t
+	case json.Number:
+		return t.String()
+	case bool:
+		return strconv.FormatBool(t)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprintf("%v", t)
+	}
+}
+
+// envifyWalk recursively descends a generic JSON tree (as produced by a
+// json.Decoder configured with UseNumber) and, for every scalar leaf that
+// isn't blocked, replaces it with a `${VAR_NAME:default}` placeholder
+// string. The mapping of var name to original default is appended to vars
+// in path order.
+func envifyWalk(path string, node interface{}, vars *[]envifyVar) interface{} {
+	switch t := node.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			childPath := k
+			if path != "" {
+				childPath = path + "." + k
+			}
+			t[k] = envifyWalk(childPath, t[k], vars)
+		}
+		return t
+	case []interface{}:
+		for i, v := range t {
+			childPath := fmt.Sprintf("%v.%v", path, i)
+			t[i] = envifyWalk(childPath, v, vars)
+		}
+		return t
+	default:
+		if envifyBlocked(path, node) {
+			return node
+		}
+		name := envifyVarName(path)
+		def := envifyLeafValue(node)
+		*vars = append(*vars, envifyVar{Name: name, Default: def})
+		return fmt.Sprintf("${%v:%v}", name, def)
+	}
+}
+
+// envifyVar is a single env var produced by the envify pass, in the order
+// it was first encountered while walking the config tree.
+type envifyVar struct {
+	Name    string
+	Default string
+}
+
+// envify takes a sanitised config tree, replaces scalar leaves with
+// `${VAR_NAME:default}` placeholders (skipping anything in the blocklist),
+// and returns the resulting tree along with the ordered list of env vars it
+// introduced.
+func envify(sanit interface{}) (interface{}, []envifyVar, error) {
+	sanitBytes, err := json.Marshal(sanit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(sanitBytes))
+	dec.UseNumber()
+
+	var generic interface{}
+	if err = dec.Decode(&generic); err != nil {
+		return nil, nil, err
+	}
+
+	var vars []envifyVar
+	return envifyWalk("", generic, &vars), vars, nil
+}
+
+func createEnvFile(path string, vars []envifyVar) {
+	var buf bytes.Buffer
+	buf.WriteString("# This file was auto generated by benthos_config_gen.\n")
+	for _, v := range vars {
+		fmt.Fprintf(&buf, "%v=%v\n", v.Name, v.Default)
+	}
+
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		panic(err)
+	}
+	fmt.Printf("Generated env file at: %v\n", path)
+}
+
+// createLintFile lints confYAML and writes the results to path, one per
+// line. It returns true if any error-severity issues were found.
+func createLintFile(path string, confYAML []byte) bool {
+	warnings, err := lint.Lint(confYAML)
+	if err != nil {
+		panic(err)
+	}
+
+	var buf bytes.Buffer
+	hasErrors := false
+	if len(warnings) == 0 {
+		buf.WriteString("No issues found.\n")
+	}
+	for _, w := range warnings {
+		fmt.Fprintf(&buf, "%v\n", w)
+		if w.Severity == lint.ErrorSeverity {
+			hasErrors = true
+		}
+	}
+
+	if err = ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		panic(err)
+	}
+	fmt.Printf("Generated lint results at: %v\n", path)
+
+	return hasErrors
+}
+
+// lintExistingConfigs lints each *.yaml file directly inside dir (its
+// subdirectories are not searched),
+// printing any issues found. It returns true if any file had error-severity
+// issues, for use as a CI-friendly exit status.
+func lintExistingConfigs(dir string) bool {
+	failed := false
+
+	matches, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, path := range matches {
+		confYAML, err := ioutil.ReadFile(path)
+		if err != nil {
+			panic(err)
+		}
+
+		warnings, err := lint.Lint(confYAML)
+		if err != nil {
+			fmt.Printf("%v: failed to lint: %v\n", path, err)
+			failed = true
+			continue
+		}
+
+		for _, w := range warnings {
+			fmt.Printf("%v: %v\n", path, w)
+			if w.Severity == lint.ErrorSeverity {
+				failed = true
+			}
+		}
+	}
+
+	return failed
+}
+
 //------------------------------------------------------------------------------
 
 func createYAML(t, path string, sanit interface{}) {
@@ -130,11 +456,149 @@ func createJSON(t, path string, sanit interface{}) {
 	fmt.Printf("Generated '%v' config at: %v\n", t, path)
 }
 
+// streamConfigForType returns a copy of base with Input/Output type set to t
+// (whichever apply) and processors arranged the same way as the single-type
+// example configs, for use as a representative stream in either generation
+// mode.
+func streamConfigForType(base Config, t string) Config {
+	conf := base
+	conf.Input.Processors = nil
+	conf.Output.Processors = nil
+	conf.Pipeline.Processors = append(conf.Pipeline.Processors, processor.NewConfig())
+
+	if _, exists := input.Constructors[t]; exists {
+		conf.Input.Type = t
+	}
+	if _, exists := output.Constructors[t]; exists {
+		conf.Output.Type = t
+	}
+	return conf
+}
+
+// generateSingleConfigs writes one example config per type in typeMap to
+// <dir>/<type>.yaml and <dir>/<type>.json, optionally alongside an
+// env-templated variant.
+func generateSingleConfigs(baseConfig Config, typeMap map[string]struct{}, configsDir string, doEnvify, doLint bool) {
+	for t := range typeMap {
+		conf := streamConfigForType(baseConfig, t)
+
+		sanit, err := conf.Sanitised()
+		if err != nil {
+			panic(err)
+		}
+
+		createYAML(t, filepath.Join(configsDir, t+".yaml"), sanit)
+		createJSON(t, filepath.Join(configsDir, t+".json"), sanit)
+
+		if doLint {
+			confYAML, err := yaml.Marshal(sanit)
+			if err != nil {
+				panic(err)
+			}
+			createLintFile(filepath.Join(configsDir, t+".lint"), confYAML)
+		}
+
+		if doEnvify {
+			envSanit, vars, err := envify(sanit)
+			if err != nil {
+				panic(err)
+			}
+			createYAML(t, filepath.Join(configsDir, "env", t+".yaml"), envSanit)
+			createEnvFile(filepath.Join(configsDir, "env", t+".env"), vars)
+		}
+	}
+}
+
+// generateStreamsManifest writes a single manifest under <dir>/streams.yaml
+// and <dir>/streams.json containing one named stream per type in typeMap,
+// sharing a top-level http and manager section.
+func generateStreamsManifest(baseConfig Config, typeMap map[string]struct{}, configsDir string) {
+	manifest := NewManifest()
+	manifest.HTTP = baseConfig.HTTP
+
+	for t := range typeMap {
+		conf := streamConfigForType(baseConfig, t)
+		manifest.Streams[t] = conf.streamConfig()
+	}
+
+	sanit, err := manifest.Sanitised()
+	if err != nil {
+		panic(err)
+	}
+
+	createYAML("streams", filepath.Join(configsDir, "streams.yaml"), sanit)
+	createJSON("streams", filepath.Join(configsDir, "streams.json"), sanit)
+}
+
 func main() {
 	configsDir := "./config"
 	flag.StringVar(&configsDir, "dir", configsDir, "The directory to write config examples")
+
+	doEnvify := false
+	flag.BoolVar(&doEnvify, "envify", doEnvify, "Also emit an env-var templated variant of each config under <dir>/env")
+
+	configServiceURL := ""
+	flag.StringVar(&configServiceURL, "config-service-url", configServiceURL, "A URL of a config service to fetch a base config from instead of using the built-in defaults")
+
+	mode := "single"
+	flag.StringVar(&mode, "mode", mode, "Config generation mode: single, streams or both")
+
+	doLint := false
+	flag.BoolVar(&doLint, "lint", doLint, "Also lint each generated config and write a companion <type>.lint file")
+
+	lintOnly := false
+	flag.BoolVar(&lintOnly, "lint-only", lintOnly, "Don't generate anything, instead lint the existing configs under -dir and exit non-zero if any fail")
 	flag.Parse()
 
+	if lintOnly {
+		if lintExistingConfigs(configsDir) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	switch mode {
+	case "single", "streams", "both":
+	default:
+		panic(fmt.Sprintf("invalid -mode '%v', must be one of: single, streams, both", mode))
+	}
+
+	baseConfig := NewConfig()
+
+	if doEnvify {
+		if err := os.MkdirAll(filepath.Join(configsDir, "env"), 0755); err != nil {
+			panic(err)
+		}
+	}
+
+	if len(configServiceURL) > 0 {
+		csConf := configservice.NewConfig()
+		csConf.URL = configServiceURL
+
+		fetcher, err := configservice.NewFetcher(csConf)
+		if err != nil {
+			panic(err)
+		}
+
+		confYAML, changed, err := fetcher.Fetch(nil)
+		if err != nil {
+			panic(err)
+		}
+		if changed {
+			fmt.Printf("Fetched base config from config service at: %v\n", configServiceURL)
+		} else {
+			confYAML = nil
+			fmt.Printf("Config service at %v has no config, using built-in defaults\n", configServiceURL)
+		}
+		if len(confYAML) > 0 {
+			var fetched Config
+			if err = yaml.Unmarshal(confYAML, &fetched); err != nil {
+				panic(err)
+			}
+			baseConfig = fetched
+		}
+	}
+
 	// Get list of all types (both input and output).
 	typeMap := map[string]struct{}{}
 	for t := range input.Constructors {
@@ -144,28 +608,27 @@ func main() {
 		typeMap[t] = struct{}{}
 	}
 
-	// Generate configs for all types.
-	for t := range typeMap {
-		conf := NewConfig()
-		conf.Input.Processors = nil
-		conf.Output.Processors = nil
-		conf.Pipeline.Processors = append(conf.Pipeline.Processors, processor.NewConfig())
+	if mode == "single" || mode == "both" {
+		generateSingleConfigs(baseConfig, typeMap, configsDir, doEnvify, doLint)
 
-		if _, exists := input.Constructors[t]; exists {
-			conf.Input.Type = t
-		}
-		if _, exists := output.Constructors[t]; exists {
-			conf.Output.Type = t
-		}
+		// Generate an example showing how to configure the config service.
+		csConf := NewConfig()
+		csConf.ConfigService.URL = "http://localhost:4195/benthos-config"
+		csConf.ConfigService.Repo = "myorg/mystream"
+		csConf.ConfigService.PollPeriod = "30s"
 
-		sanit, err := conf.Sanitised()
+		csSanit, err := csConf.Sanitised()
 		if err != nil {
 			panic(err)
 		}
+		createYAML("configservice", filepath.Join(configsDir, "configservice.yaml"), csSanit)
+	}
 
-		createYAML(t, filepath.Join(configsDir, t+".yaml"), sanit)
-		createJSON(t, filepath.Join(configsDir, t+".json"), sanit)
+	if mode == "streams" || mode == "both" {
+		generateStreamsManifest(baseConfig, typeMap, configsDir)
 	}
+
+	createJSON("schema", filepath.Join(configsDir, "schema.json"), lint.Schema())
 }
 
 //------------------------------------------------------------------------------
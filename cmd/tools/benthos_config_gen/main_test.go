@@ -0,0 +1,78 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestManifestRoundTrip(t *testing.T) {
+	manifest := NewManifest()
+
+	fooConf := streamConfigForType(NewConfig(), "kafka")
+	barConf := streamConfigForType(NewConfig(), "file")
+
+	manifest.Streams["foo"] = fooConf.streamConfig()
+	manifest.Streams["bar"] = barConf.streamConfig()
+
+	confBytes, err := yaml.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var roundTripped Manifest
+	if err = yaml.Unmarshal(confBytes, &roundTripped); err != nil {
+		t.Fatal(err)
+	}
+
+	if exp, act := len(manifest.Streams), len(roundTripped.Streams); exp != act {
+		t.Fatalf("wrong number of streams: %v != %v", act, exp)
+	}
+
+	for id, conf := range manifest.Streams {
+		rtConf, exists := roundTripped.Streams[id]
+		if !exists {
+			t.Fatalf("stream '%v' missing after round trip", id)
+		}
+		if exp, act := conf.Input.Type, rtConf.Input.Type; exp != act {
+			t.Errorf("stream '%v': wrong input type: %v != %v", id, act, exp)
+		}
+		if exp, act := conf.Output.Type, rtConf.Output.Type; exp != act {
+			t.Errorf("stream '%v': wrong output type: %v != %v", id, act, exp)
+		}
+	}
+}
+
+func TestManifestSanitised(t *testing.T) {
+	manifest := NewManifest()
+	manifest.Streams["foo"] = streamConfigForType(NewConfig(), "kafka").streamConfig()
+
+	sanit, err := manifest.Sanitised()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = yaml.Marshal(sanit); err != nil {
+		t.Fatal(err)
+	}
+}
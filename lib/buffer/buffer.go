@@ -0,0 +1,108 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package buffer holds the configuration types for Benthos buffers, along
+// with the registry of constructors (Constructors) that the generator and
+// linter use to discover the set of supported types and their field specs.
+package buffer
+
+import "github.com/Jeffail/benthos/lib/config/docs"
+
+//------------------------------------------------------------------------------
+
+// TypeSpec is the internal representation of a buffer type, holding the
+// metadata used to document, lint and generate example configs for it.
+type TypeSpec struct {
+	Description string
+	FieldSpecs  docs.FieldSpecs
+}
+
+//------------------------------------------------------------------------------
+
+// NoneConfig contains config fields for the none buffer type, which has
+// none.
+type NoneConfig struct{}
+
+// NewNoneConfig creates a new NoneConfig with default values.
+func NewNoneConfig() NoneConfig {
+	return NoneConfig{}
+}
+
+// MemoryConfig contains config fields for the memory buffer type.
+type MemoryConfig struct {
+	Limit int `json:"limit" yaml:"limit"`
+}
+
+// NewMemoryConfig creates a new MemoryConfig with default values.
+func NewMemoryConfig() MemoryConfig {
+	return MemoryConfig{Limit: 500000000}
+}
+
+//------------------------------------------------------------------------------
+
+// Config is the all-encompassing configuration struct for all buffer types,
+// where only the config field relevant to Type is parsed at runtime.
+type Config struct {
+	Type   string       `json:"type" yaml:"type"`
+	None   NoneConfig   `json:"none" yaml:"none"`
+	Memory MemoryConfig `json:"memory" yaml:"memory"`
+}
+
+// NewConfig returns a new configuration with default values.
+func NewConfig() Config {
+	return Config{
+		Type:   "none",
+		None:   NewNoneConfig(),
+		Memory: NewMemoryConfig(),
+	}
+}
+
+// SanitiseConfig returns a sanitised version of conf, including only the
+// type field and the sub-config relevant to conf.Type.
+func SanitiseConfig(conf Config) (interface{}, error) {
+	sanitised := map[string]interface{}{
+		"type": conf.Type,
+	}
+
+	switch conf.Type {
+	case "none":
+		sanitised["none"] = conf.None
+	case "memory":
+		sanitised["memory"] = conf.Memory
+	}
+
+	return sanitised, nil
+}
+
+//------------------------------------------------------------------------------
+
+// Constructors is a registry of all buffer types, keyed by type name, along
+// with the FieldSpecs used to lint and document their configs.
+var Constructors = map[string]TypeSpec{
+	"none": {
+		Description: "An empty buffer that performs no buffering at all.",
+	},
+	"memory": {
+		Description: "Buffers messages in memory up to a size limit.",
+		FieldSpecs: docs.FieldSpecs{
+			{Name: "limit", Type: docs.FieldNumber, Description: "The maximum buffer size in bytes."},
+		},
+	},
+}
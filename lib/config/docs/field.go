@@ -0,0 +1,71 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package docs contains the field metadata that component TypeSpecs expose
+// (via their FieldSpecs member) for use by documentation generation,
+// config linting and JSON-Schema generation. It intentionally has no
+// dependency on any specific component package so that input, output,
+// buffer, pipeline and processor can all depend on it without cycles.
+package docs
+
+//------------------------------------------------------------------------------
+
+// FieldType enumerates the JSON/YAML shapes a config field may take.
+type FieldType string
+
+// FieldType values.
+const (
+	FieldString  FieldType = "string"
+	FieldNumber  FieldType = "number"
+	FieldBool    FieldType = "bool"
+	FieldObject  FieldType = "object"
+	FieldArray   FieldType = "array"
+	FieldUnknown FieldType = ""
+)
+
+// FieldSpec describes a single field of a component config.
+type FieldSpec struct {
+	// Name is the field name as it appears in YAML/JSON.
+	Name string
+
+	// Type is the expected shape of the field's value.
+	Type FieldType
+
+	// Description is a short, human readable explanation of the field,
+	// reused by documentation generation.
+	Description string
+
+	// Deprecated is set when the field is kept only for backwards
+	// compatibility and should no longer be used in new configs.
+	Deprecated bool
+}
+
+// FieldSpecs is a list of FieldSpec belonging to a single component type.
+type FieldSpecs []FieldSpec
+
+// Find returns the FieldSpec with the given name, if one exists.
+func (f FieldSpecs) Find(name string) (FieldSpec, bool) {
+	for _, spec := range f {
+		if spec.Name == name {
+			return spec, true
+		}
+	}
+	return FieldSpec{}, false
+}
@@ -0,0 +1,304 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package lint provides static analysis of rendered Benthos config files,
+// checking them against the field specs declared by each component type so
+// that issues (unknown fields, deprecated options, type mismatches, unset
+// env vars) can be caught in CI rather than at runtime.
+package lint
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/Jeffail/benthos/lib/buffer"
+	"github.com/Jeffail/benthos/lib/config/docs"
+	"github.com/Jeffail/benthos/lib/input"
+	"github.com/Jeffail/benthos/lib/output"
+	"github.com/Jeffail/benthos/lib/processor"
+	yaml "gopkg.in/yaml.v3"
+)
+
+//------------------------------------------------------------------------------
+
+// Severity distinguishes a Warning that merely looks suspicious from an
+// Error that is very likely to break at runtime.
+type Severity int
+
+// Severity values.
+const (
+	WarningSeverity Severity = iota
+	ErrorSeverity
+)
+
+func (s Severity) String() string {
+	if s == ErrorSeverity {
+		return "error"
+	}
+	return "warning"
+}
+
+// Warning is a single issue found while linting a config.
+type Warning struct {
+	Path     string
+	Severity Severity
+	Message  string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%v: %v: %v", w.Path, w.Severity, w.Message)
+}
+
+//------------------------------------------------------------------------------
+
+// envVarPattern matches the `${VAR_NAME:default}` / `${VAR_NAME}` syntax
+// produced by the generator's -envify mode.
+var envVarPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:[^}]*)?\}`)
+
+// specLookup returns the FieldSpecs registered against a given component
+// type for one of the component sections (input, output, buffer or
+// processor), plus whether that type is known at all.
+func specLookup(section, typeName string) (docs.FieldSpecs, bool) {
+	switch section {
+	case "input":
+		spec, exists := input.Constructors[typeName]
+		if !exists {
+			return nil, false
+		}
+		return spec.FieldSpecs, true
+	case "output":
+		spec, exists := output.Constructors[typeName]
+		if !exists {
+			return nil, false
+		}
+		return spec.FieldSpecs, true
+	case "buffer":
+		spec, exists := buffer.Constructors[typeName]
+		if !exists {
+			return nil, false
+		}
+		return spec.FieldSpecs, true
+	case "processor":
+		spec, exists := processor.Constructors[typeName]
+		if !exists {
+			return nil, false
+		}
+		return spec.FieldSpecs, true
+	}
+	return nil, false
+}
+
+//------------------------------------------------------------------------------
+
+// Lint parses a rendered Benthos config (YAML) and returns any issues found
+// by comparing each configured component against its declared FieldSpecs.
+func Lint(conf []byte) ([]Warning, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(conf, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %v", err)
+	}
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("expected a top level mapping, got %v", doc.Kind)
+	}
+
+	var warnings []Warning
+
+	if n := mappingValue(doc, "input"); n != nil {
+		warnings = append(warnings, lintComponent("input", "input", n)...)
+		if procs := mappingValue(n, "processors"); procs != nil {
+			warnings = append(warnings, lintProcessors("input.processors", procs)...)
+		}
+	}
+	if n := mappingValue(doc, "output"); n != nil {
+		warnings = append(warnings, lintComponent("output", "output", n)...)
+		if procs := mappingValue(n, "processors"); procs != nil {
+			warnings = append(warnings, lintProcessors("output.processors", procs)...)
+		}
+	}
+	if n := mappingValue(doc, "buffer"); n != nil {
+		warnings = append(warnings, lintComponent("buffer", "buffer", n)...)
+	}
+	if n := mappingValue(doc, "pipeline"); n != nil {
+		if procs := mappingValue(n, "processors"); procs != nil {
+			warnings = append(warnings, lintProcessors("pipeline.processors", procs)...)
+		}
+	}
+
+	warnings = append(warnings, lintEnvVars("", doc)...)
+
+	return warnings, nil
+}
+
+func lintProcessors(path string, seq *yaml.Node) []Warning {
+	if seq.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var warnings []Warning
+	for i, procNode := range seq.Content {
+		warnings = append(warnings, lintComponent("processor", fmt.Sprintf("%v.%v", path, i), procNode)...)
+	}
+	return warnings
+}
+
+// lintComponent checks a single `{type: foo, foo: {...}}`-shaped node
+// against the FieldSpecs registered for its type.
+func lintComponent(section, path string, node *yaml.Node) []Warning {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	typeNode := mappingValue(node, "type")
+	if typeNode == nil {
+		return nil
+	}
+	typeName := typeNode.Value
+
+	specs, known := specLookup(section, typeName)
+	if !known {
+		return []Warning{{
+			Path:     path,
+			Severity: ErrorSeverity,
+			Message:  fmt.Sprintf("unrecognised %v type '%v'", section, typeName),
+		}}
+	}
+
+	fieldsNode := mappingValue(node, typeName)
+	if fieldsNode == nil || fieldsNode.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var warnings []Warning
+	for i := 0; i+1 < len(fieldsNode.Content); i += 2 {
+		key := fieldsNode.Content[i]
+		val := fieldsNode.Content[i+1]
+		fieldPath := fmt.Sprintf("%v.%v.%v", path, typeName, key.Value)
+
+		spec, exists := specs.Find(key.Value)
+		if !exists {
+			warnings = append(warnings, Warning{
+				Path:     fieldPath,
+				Severity: WarningSeverity,
+				Message:  fmt.Sprintf("unknown field '%v' for %v type '%v'", key.Value, section, typeName),
+			})
+			continue
+		}
+
+		if spec.Deprecated {
+			warnings = append(warnings, Warning{
+				Path:     fieldPath,
+				Severity: WarningSeverity,
+				Message:  fmt.Sprintf("field '%v' is deprecated", key.Value),
+			})
+		}
+
+		if mismatch, expected, actual := typeMismatch(spec.Type, val); mismatch {
+			warnings = append(warnings, Warning{
+				Path:     fieldPath,
+				Severity: ErrorSeverity,
+				Message:  fmt.Sprintf("field '%v' expected type %v, got %v", key.Value, expected, actual),
+			})
+		}
+	}
+	return warnings
+}
+
+// typeMismatch reports whether a scalar val's resolved YAML tag is
+// incompatible with an expected bool or number type, skipping fields whose
+// value is an env var placeholder (which may resolve to any type at
+// runtime). Non-scalar values and the other expected types are not checked.
+func typeMismatch(expected docs.FieldType, val *yaml.Node) (mismatch bool, expStr, actStr string) {
+	if expected == docs.FieldUnknown || val.Kind != yaml.ScalarNode {
+		return false, "", ""
+	}
+	if envVarPattern.MatchString(val.Value) {
+		return false, "", ""
+	}
+
+	switch expected {
+	case docs.FieldBool:
+		if val.Tag != "!!bool" {
+			return true, string(expected), strings.TrimPrefix(val.Tag, "!!")
+		}
+	case docs.FieldNumber:
+		if val.Tag != "!!int" && val.Tag != "!!float" {
+			return true, string(expected), strings.TrimPrefix(val.Tag, "!!")
+		}
+	}
+	return false, "", ""
+}
+
+// lintEnvVars walks every scalar node in the tree and flags `${VAR:...}`
+// references to environment variables that aren't set and have no default.
+func lintEnvVars(path string, node *yaml.Node) []Warning {
+	var warnings []Warning
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			warnings = append(warnings, lintEnvVars(childPath, node.Content[i+1])...)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			warnings = append(warnings, lintEnvVars(fmt.Sprintf("%v.%v", path, i), child)...)
+		}
+	case yaml.ScalarNode:
+		for _, match := range envVarPattern.FindAllStringSubmatch(node.Value, -1) {
+			name, hasDefault := match[1], len(match[2]) > 0
+			if hasDefault {
+				continue
+			}
+			if _, set := os.LookupEnv(name); !set {
+				warnings = append(warnings, Warning{
+					Path:     path,
+					Severity: WarningSeverity,
+					Message:  fmt.Sprintf("env var '%v' has no default and isn't currently set", name),
+				})
+			}
+		}
+	}
+
+	return warnings
+}
+
+// mappingValue returns the value node for key within a mapping node, or nil
+// if node isn't a mapping or doesn't contain key.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
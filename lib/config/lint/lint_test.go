@@ -0,0 +1,138 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLintUnknownType(t *testing.T) {
+	conf := []byte(`
+input:
+  type: definitely_not_a_real_type
+  definitely_not_a_real_type: {}
+`)
+	warnings, err := Lint(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, w := range warnings {
+		if w.Severity == ErrorSeverity && w.Path == "input" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected an error warning for unrecognised input type, got: %v", warnings)
+	}
+}
+
+func TestLintKnownTypeUnknownField(t *testing.T) {
+	conf := []byte(`
+input:
+  type: stdin
+  stdin:
+    delimiter: "\n"
+    not_a_real_field: true
+`)
+	warnings, err := Lint(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, w := range warnings {
+		if w.Severity == WarningSeverity && w.Path == "input.stdin.not_a_real_field" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a warning for an unknown field on a known type, got: %v", warnings)
+	}
+}
+
+func TestLintFieldTypeMismatch(t *testing.T) {
+	conf := []byte(`
+input:
+  type: stdin
+  stdin:
+    multipart: "yes please"
+`)
+	warnings, err := Lint(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, w := range warnings {
+		if w.Severity == ErrorSeverity && w.Path == "input.stdin.multipart" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a type mismatch error for a string where a bool is expected, got: %v", warnings)
+	}
+}
+
+func TestLintUnsetEnvVar(t *testing.T) {
+	os.Unsetenv("BENTHOS_LINT_TEST_VAR")
+
+	conf := []byte(`
+input:
+  type: stdin
+  stdin:
+    delimiter: "${BENTHOS_LINT_TEST_VAR}"
+`)
+	warnings, err := Lint(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, w := range warnings {
+		if w.Severity == WarningSeverity && w.Path == "input.stdin.delimiter" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a warning for unset env var, got: %v", warnings)
+	}
+}
+
+func TestLintEnvVarWithDefaultIsFine(t *testing.T) {
+	conf := []byte(`
+input:
+  type: stdin
+  stdin:
+    delimiter: "${BENTHOS_LINT_TEST_VAR:\n}"
+`)
+	warnings, err := Lint(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range warnings {
+		if w.Path == "input.stdin.delimiter" {
+			t.Errorf("did not expect a warning for env var with default, got: %v", w)
+		}
+	}
+}
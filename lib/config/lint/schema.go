@@ -0,0 +1,135 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"github.com/Jeffail/benthos/lib/buffer"
+	"github.com/Jeffail/benthos/lib/config/docs"
+	"github.com/Jeffail/benthos/lib/input"
+	"github.com/Jeffail/benthos/lib/output"
+	"github.com/Jeffail/benthos/lib/processor"
+)
+
+//------------------------------------------------------------------------------
+
+// jsonSchemaType maps a docs.FieldType to its JSON-Schema "type" keyword.
+func jsonSchemaType(t docs.FieldType) string {
+	switch t {
+	case docs.FieldString:
+		return "string"
+	case docs.FieldNumber:
+		return "number"
+	case docs.FieldBool:
+		return "boolean"
+	case docs.FieldObject:
+		return "object"
+	case docs.FieldArray:
+		return "array"
+	default:
+		return "string"
+	}
+}
+
+// componentSchema builds a JSON-Schema "oneOf" listing every registered
+// type for a section, each requiring its own "type" discriminator plus a
+// properties object built from its FieldSpecs.
+func componentSchema(types map[string]docs.FieldSpecs) map[string]interface{} {
+	oneOf := make([]interface{}, 0, len(types))
+	for typeName, specs := range types {
+		props := map[string]interface{}{
+			"type": map[string]interface{}{"const": typeName},
+		}
+		fieldProps := map[string]interface{}{}
+		for _, spec := range specs {
+			fieldSchema := map[string]interface{}{
+				"type": jsonSchemaType(spec.Type),
+			}
+			if len(spec.Description) > 0 {
+				fieldSchema["description"] = spec.Description
+			}
+			if spec.Deprecated {
+				fieldSchema["deprecated"] = true
+			}
+			fieldProps[spec.Name] = fieldSchema
+		}
+		if len(fieldProps) > 0 {
+			props[typeName] = map[string]interface{}{
+				"type":       "object",
+				"properties": fieldProps,
+			}
+		}
+		oneOf = append(oneOf, map[string]interface{}{
+			"type":       "object",
+			"properties": props,
+			"required":   []interface{}{"type"},
+		})
+	}
+	return map[string]interface{}{"oneOf": oneOf}
+}
+
+// Schema builds a JSON-Schema document describing the input, buffer,
+// pipeline and output sections of a Benthos config, derived from the
+// FieldSpecs of all registered input, buffer, processor and output types.
+// Other top-level sections, such as http and config_service, are not
+// described.
+func Schema() map[string]interface{} {
+	inputTypes := map[string]docs.FieldSpecs{}
+	for t, spec := range input.Constructors {
+		inputTypes[t] = spec.FieldSpecs
+	}
+
+	outputTypes := map[string]docs.FieldSpecs{}
+	for t, spec := range output.Constructors {
+		outputTypes[t] = spec.FieldSpecs
+	}
+
+	bufferTypes := map[string]docs.FieldSpecs{}
+	for t, spec := range buffer.Constructors {
+		bufferTypes[t] = spec.FieldSpecs
+	}
+
+	processorTypes := map[string]docs.FieldSpecs{}
+	for t, spec := range processor.Constructors {
+		processorTypes[t] = spec.FieldSpecs
+	}
+
+	processorSchema := componentSchema(processorTypes)
+
+	return map[string]interface{}{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"title":   "Benthos Config",
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"input":  componentSchema(inputTypes),
+			"output": componentSchema(outputTypes),
+			"buffer": componentSchema(bufferTypes),
+			"pipeline": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"processors": map[string]interface{}{
+						"type":  "array",
+						"items": processorSchema,
+					},
+				},
+			},
+		},
+	}
+}
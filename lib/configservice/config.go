@@ -0,0 +1,45 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package configservice provides a client for fetching rendered Benthos
+// configs from a remote HTTP "config service", allowing stream configs to be
+// centralised instead of baked into each instance.
+package configservice
+
+// Config contains fields for configuring a connection to a remote config
+// service.
+type Config struct {
+	URL        string `json:"url" yaml:"url"`
+	Repo       string `json:"repo" yaml:"repo"`
+	Ref        string `json:"ref" yaml:"ref"`
+	PrivateKey string `json:"private_key" yaml:"private_key"`
+	PollPeriod string `json:"poll_period" yaml:"poll_period"`
+}
+
+// NewConfig returns a Config with default values.
+func NewConfig() Config {
+	return Config{
+		URL:        "",
+		Repo:       "",
+		Ref:        "master",
+		PrivateKey: "",
+		PollPeriod: "",
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package configservice
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+//------------------------------------------------------------------------------
+
+// Status values returned by a config service in its response body.
+const (
+	StatusOK            = "ok"
+	StatusNotConfigured = "not-configured"
+	StatusError         = "error"
+)
+
+// SignatureHeader is the HTTP header that carries the base64 encoded ed25519
+// signature of the request body, allowing a config service to verify the
+// authenticity of the caller.
+const SignatureHeader = "X-Benthos-Signature"
+
+//------------------------------------------------------------------------------
+
+type fetchRequest struct {
+	Repo    string `json:"repo"`
+	Ref     string `json:"ref"`
+	Current string `json:"current"`
+}
+
+type fetchResponse struct {
+	Config string `json:"config"`
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+//------------------------------------------------------------------------------
+
+// Fetcher fetches a rendered Benthos config from a remote config service over
+// HTTP, optionally signing each request with an ed25519 private key so the
+// service can verify the caller.
+type Fetcher struct {
+	conf   Config
+	key    ed25519.PrivateKey
+	client *http.Client
+}
+
+// NewFetcher creates a Fetcher from a Config.
+func NewFetcher(conf Config) (*Fetcher, error) {
+	f := &Fetcher{
+		conf:   conf,
+		client: http.DefaultClient,
+	}
+	if len(conf.PrivateKey) > 0 {
+		keyBytes, err := base64.StdEncoding.DecodeString(conf.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode private_key: %v", err)
+		}
+		if len(keyBytes) != ed25519.PrivateKeySize {
+			return nil, fmt.Errorf("private_key has invalid size: %v != %v", len(keyBytes), ed25519.PrivateKeySize)
+		}
+		f.key = ed25519.PrivateKey(keyBytes)
+	}
+	return f, nil
+}
+
+// Fetch POSTs the current raw config (which may be empty) to the config
+// service and returns the raw YAML of the config it returns. The returned
+// bool is false when the service reports that it has no config for this
+// repo/ref (status "not-configured"), in which case callers should keep
+// using their current config.
+func (f *Fetcher) Fetch(current []byte) ([]byte, bool, error) {
+	reqBody, err := json.Marshal(fetchRequest{
+		Repo:    f.conf.Repo,
+		Ref:     f.conf.Ref,
+		Current: string(current),
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, f.conf.URL, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if f.key != nil {
+		sig := ed25519.Sign(f.key, reqBody)
+		req.Header.Set(SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+	}
+
+	res, err := f.client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer res.Body.Close()
+
+	resBytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var fRes fetchResponse
+	if err = json.Unmarshal(resBytes, &fRes); err != nil {
+		return nil, false, fmt.Errorf("failed to parse config service response: %v", err)
+	}
+
+	switch fRes.Status {
+	case StatusOK:
+		return []byte(fRes.Config), true, nil
+	case StatusNotConfigured:
+		return nil, false, nil
+	case StatusError:
+		return nil, false, fmt.Errorf("config service returned error: %v", fRes.Error)
+	}
+	return nil, false, fmt.Errorf("config service returned unrecognised status: %v", fRes.Status)
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package configservice
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetcherFetchOK(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req fetchRequest
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = json.Unmarshal(body, &req); err != nil {
+			t.Fatal(err)
+		}
+		if req.Repo != "foo/bar" {
+			t.Errorf("unexpected repo: %v", req.Repo)
+		}
+		res, _ := json.Marshal(fetchResponse{
+			Config: "input:\n  type: kafka\n",
+			Status: StatusOK,
+		})
+		w.Write(res)
+	}))
+	defer server.Close()
+
+	conf := NewConfig()
+	conf.URL = server.URL
+	conf.Repo = "foo/bar"
+
+	f, err := NewFetcher(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	confYAML, changed, err := f.Fetch(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Error("expected changed to be true")
+	}
+	if exp, act := "input:\n  type: kafka\n", string(confYAML); exp != act {
+		t.Errorf("wrong config: %v != %v", act, exp)
+	}
+}
+
+func TestFetcherFetchNotConfigured(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, _ := json.Marshal(fetchResponse{Status: StatusNotConfigured})
+		w.Write(res)
+	}))
+	defer server.Close()
+
+	conf := NewConfig()
+	conf.URL = server.URL
+
+	f, err := NewFetcher(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, changed, err := f.Fetch(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed {
+		t.Error("expected changed to be false")
+	}
+}
+
+func TestFetcherSignsRequests(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sigB64 := r.Header.Get(SignatureHeader)
+		sig, err := base64.StdEncoding.DecodeString(sigB64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ed25519.Verify(pub, body, sig) {
+			t.Error("signature did not verify")
+		}
+		res, _ := json.Marshal(fetchResponse{Status: StatusNotConfigured})
+		w.Write(res)
+	}))
+	defer server.Close()
+
+	conf := NewConfig()
+	conf.URL = server.URL
+	conf.PrivateKey = base64.StdEncoding.EncodeToString(priv)
+
+	f, err := NewFetcher(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err = f.Fetch(nil); err != nil {
+		t.Fatal(err)
+	}
+}
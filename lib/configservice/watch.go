@@ -0,0 +1,104 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package configservice
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+//------------------------------------------------------------------------------
+
+// Watch starts a background goroutine that re-fetches the config, passing
+// the last fetched raw config as `current`, whenever the process receives
+// SIGHUP or (if conf.PollPeriod is set) on that interval. A PollPeriod that
+// doesn't parse as a duration makes Watch return an error.
+// Whenever the service returns a new config onUpdate is called with the raw
+// YAML, allowing the caller to hot-swap its running stream. onUpdate errors
+// are forwarded to onError rather than stopping the watch loop.
+//
+// Watch only delivers the refreshed config; it has no knowledge of how the
+// caller is running it. Decoding the YAML into a stream.Config and passing
+// it to a lib/stream/manager.Type (e.g. via Update) is the caller's
+// responsibility, left to whatever wires up both packages in the service
+// entrypoint.
+//
+// The returned function stops the watch loop and must be called to release
+// the signal notification.
+func (f *Fetcher) Watch(initial []byte, onUpdate func(confYAML []byte) error, onError func(error)) (func(), error) {
+	var pollTicker *time.Ticker
+	if len(f.conf.PollPeriod) > 0 {
+		period, err := time.ParseDuration(f.conf.PollPeriod)
+		if err != nil {
+			return nil, err
+		}
+		pollTicker = time.NewTicker(period)
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+
+	closeChan := make(chan struct{})
+
+	go func() {
+		current := initial
+		refresh := func() {
+			next, changed, err := f.Fetch(current)
+			if err != nil {
+				onError(err)
+				return
+			}
+			if !changed {
+				return
+			}
+			current = next
+			if err = onUpdate(next); err != nil {
+				onError(err)
+			}
+		}
+
+		var tickChan <-chan time.Time
+		if pollTicker != nil {
+			tickChan = pollTicker.C
+		}
+
+		for {
+			select {
+			case <-sigChan:
+				refresh()
+			case <-tickChan:
+				refresh()
+			case <-closeChan:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigChan)
+		if pollTicker != nil {
+			pollTicker.Stop()
+		}
+		close(closeChan)
+	}, nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package configservice
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWatchPollTriggersRefresh(t *testing.T) {
+	served := "input:\n  type: stdin\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, _ := json.Marshal(fetchResponse{Config: served, Status: StatusOK})
+		w.Write(res)
+	}))
+	defer server.Close()
+
+	conf := NewConfig()
+	conf.URL = server.URL
+	conf.PollPeriod = "10ms"
+
+	f, err := NewFetcher(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updates := make(chan []byte, 10)
+	stop, err := f.Watch(nil, func(confYAML []byte) error {
+		updates <- confYAML
+		return nil
+	}, func(err error) {
+		t.Errorf("unexpected watch error: %v", err)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	select {
+	case got := <-updates:
+		if exp, act := served, string(got); exp != act {
+			t.Errorf("wrong config: %v != %v", act, exp)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for polled update")
+	}
+}
+
+func TestWatchSIGHUPTriggersRefresh(t *testing.T) {
+	var mu sync.Mutex
+	first := true
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		isFirst := first
+		first = false
+		mu.Unlock()
+
+		conf := "input:\n  type: stdin\n"
+		if !isFirst {
+			conf = "input:\n  type: file\n"
+		}
+		res, _ := json.Marshal(fetchResponse{Config: conf, Status: StatusOK})
+		w.Write(res)
+	}))
+	defer server.Close()
+
+	conf := NewConfig()
+	conf.URL = server.URL
+
+	f, err := NewFetcher(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	initial, _, err := f.Fetch(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updates := make(chan []byte, 10)
+	stop, err := f.Watch(initial, func(confYAML []byte) error {
+		updates <- confYAML
+		return nil
+	}, func(err error) {
+		t.Errorf("unexpected watch error: %v", err)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case got := <-updates:
+		if exp, act := "input:\n  type: file\n", string(got); exp != act {
+			t.Errorf("wrong config: %v != %v", act, exp)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for SIGHUP-triggered update")
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package input holds the configuration types for Benthos input sources,
+// along with the registry of constructors (Constructors) that the generator
+// and linter use to discover the set of supported types and their field
+// specs.
+package input
+
+import (
+	"github.com/Jeffail/benthos/lib/config/docs"
+	"github.com/Jeffail/benthos/lib/processor"
+)
+
+//------------------------------------------------------------------------------
+
+// TypeSpec is the internal representation of an input type, holding the
+// metadata used to document, lint and generate example configs for it.
+type TypeSpec struct {
+	Description string
+	FieldSpecs  docs.FieldSpecs
+}
+
+//------------------------------------------------------------------------------
+
+// StdinConfig contains config fields for the stdin input type.
+type StdinConfig struct {
+	Multipart bool   `json:"multipart" yaml:"multipart"`
+	Delimiter string `json:"delimiter" yaml:"delimiter"`
+}
+
+// NewStdinConfig creates a new StdinConfig with default values.
+func NewStdinConfig() StdinConfig {
+	return StdinConfig{
+		Multipart: false,
+		Delimiter: "",
+	}
+}
+
+// FileConfig contains config fields for the file input type.
+type FileConfig struct {
+	Path      string `json:"path" yaml:"path"`
+	Multipart bool   `json:"multipart" yaml:"multipart"`
+	MaxBuffer int    `json:"max_buffer" yaml:"max_buffer"`
+}
+
+// NewFileConfig creates a new FileConfig with default values.
+func NewFileConfig() FileConfig {
+	return FileConfig{
+		Path:      "",
+		Multipart: false,
+		MaxBuffer: 1000000,
+	}
+}
+
+// KafkaConfig contains config fields for the kafka input type.
+type KafkaConfig struct {
+	Addresses       []string `json:"addresses" yaml:"addresses"`
+	Topic           string   `json:"topic" yaml:"topic"`
+	ClientID        string   `json:"client_id" yaml:"client_id"`
+	ConsumerGroup   string   `json:"consumer_group" yaml:"consumer_group"`
+	StartFromOldest bool     `json:"start_from_oldest" yaml:"start_from_oldest"`
+}
+
+// NewKafkaConfig creates a new KafkaConfig with default values.
+func NewKafkaConfig() KafkaConfig {
+	return KafkaConfig{
+		Addresses:       []string{"localhost:9092"},
+		Topic:           "",
+		ClientID:        "benthos",
+		ConsumerGroup:   "benthos",
+		StartFromOldest: true,
+	}
+}
+
+// HTTPClientConfig contains config fields for the http_client input type.
+type HTTPClientConfig struct {
+	URL  string `json:"url" yaml:"url"`
+	Verb string `json:"verb" yaml:"verb"`
+}
+
+// NewHTTPClientConfig creates a new HTTPClientConfig with default values.
+func NewHTTPClientConfig() HTTPClientConfig {
+	return HTTPClientConfig{
+		URL:  "",
+		Verb: "GET",
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// Config is the all-encompassing configuration struct for all input types,
+// where only the config field relevant to Type is parsed at runtime.
+type Config struct {
+	Type       string             `json:"type" yaml:"type"`
+	Stdin      StdinConfig        `json:"stdin" yaml:"stdin"`
+	File       FileConfig         `json:"file" yaml:"file"`
+	Kafka      KafkaConfig        `json:"kafka" yaml:"kafka"`
+	HTTPClient HTTPClientConfig   `json:"http_client" yaml:"http_client"`
+	Processors []processor.Config `json:"processors" yaml:"processors"`
+}
+
+// NewConfig returns a new configuration with default values.
+func NewConfig() Config {
+	return Config{
+		Type:       "stdin",
+		Stdin:      NewStdinConfig(),
+		File:       NewFileConfig(),
+		Kafka:      NewKafkaConfig(),
+		HTTPClient: NewHTTPClientConfig(),
+		Processors: []processor.Config{},
+	}
+}
+
+// SanitiseConfig returns a sanitised version of conf, including only the
+// type field and the sub-config relevant to conf.Type.
+func SanitiseConfig(conf Config) (interface{}, error) {
+	sanitised := map[string]interface{}{
+		"type": conf.Type,
+	}
+
+	switch conf.Type {
+	case "stdin":
+		sanitised["stdin"] = conf.Stdin
+	case "file":
+		sanitised["file"] = conf.File
+	case "kafka":
+		sanitised["kafka"] = conf.Kafka
+	case "http_client":
+		sanitised["http_client"] = conf.HTTPClient
+	}
+
+	if len(conf.Processors) > 0 {
+		procs := make([]interface{}, len(conf.Processors))
+		for i, p := range conf.Processors {
+			sanProc, err := processor.SanitiseConfig(p)
+			if err != nil {
+				return nil, err
+			}
+			procs[i] = sanProc
+		}
+		sanitised["processors"] = procs
+	}
+
+	return sanitised, nil
+}
+
+//------------------------------------------------------------------------------
+
+// Constructors is a registry of all input types, keyed by type name, along
+// with the FieldSpecs used to lint and document their configs.
+var Constructors = map[string]TypeSpec{
+	"stdin": {
+		Description: "Consumes lines of data from stdin.",
+		FieldSpecs: docs.FieldSpecs{
+			{Name: "multipart", Type: docs.FieldBool, Description: "Interpret consecutive empty lines as the end of a message."},
+			{Name: "delimiter", Type: docs.FieldString, Description: "A custom delimiter to split input on, defaults to newline."},
+		},
+	},
+	"file": {
+		Description: "Reads lines from a file on disk, one message per line.",
+		FieldSpecs: docs.FieldSpecs{
+			{Name: "path", Type: docs.FieldString, Description: "The path of the file to read."},
+			{Name: "multipart", Type: docs.FieldBool, Description: "Interpret consecutive empty lines as the end of a message."},
+			{Name: "max_buffer", Type: docs.FieldNumber, Description: "The maximum message size able to be read."},
+		},
+	},
+	"kafka": {
+		Description: "Connects to a Kafka broker and consumes messages from a topic.",
+		FieldSpecs: docs.FieldSpecs{
+			{Name: "addresses", Type: docs.FieldArray, Description: "A list of broker addresses to connect to."},
+			{Name: "topic", Type: docs.FieldString, Description: "The topic to consume from."},
+			{Name: "client_id", Type: docs.FieldString, Description: "An identifier for the client connection."},
+			{Name: "consumer_group", Type: docs.FieldString, Description: "An identifier for the consumer group."},
+			{Name: "start_from_oldest", Type: docs.FieldBool, Description: "Whether to consume from the oldest available offset."},
+		},
+	},
+	"http_client": {
+		Description: "Polls an HTTP endpoint for new messages.",
+		FieldSpecs: docs.FieldSpecs{
+			{Name: "url", Type: docs.FieldString, Description: "The URL to poll."},
+			{Name: "verb", Type: docs.FieldString, Description: "The HTTP verb to use for the request."},
+		},
+	},
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package manager holds the configuration for the service's shared resource
+// manager: the caches, rate limits and other cross-stream resources that a
+// Manifest's named streams can all reference by label. This is distinct
+// from lib/stream/manager, which manages the set of running streams
+// themselves rather than the resources they share.
+package manager
+
+//------------------------------------------------------------------------------
+
+// Config contains the configuration fields for shared, named resources
+// available to every stream under a Manifest.
+type Config struct {
+	Caches     map[string]CacheConfig     `json:"caches" yaml:"caches"`
+	RateLimits map[string]RateLimitConfig `json:"rate_limits" yaml:"rate_limits"`
+}
+
+// NewConfig returns a new configuration with default values.
+func NewConfig() Config {
+	return Config{
+		Caches:     map[string]CacheConfig{},
+		RateLimits: map[string]RateLimitConfig{},
+	}
+}
+
+// CacheConfig contains config fields for a single named cache resource.
+type CacheConfig struct {
+	Type string `json:"type" yaml:"type"`
+}
+
+// RateLimitConfig contains config fields for a single named rate limit
+// resource.
+type RateLimitConfig struct {
+	Type string `json:"type" yaml:"type"`
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package output holds the configuration types for Benthos output sinks,
+// along with the registry of constructors (Constructors) that the generator
+// and linter use to discover the set of supported types and their field
+// specs.
+package output
+
+import (
+	"github.com/Jeffail/benthos/lib/config/docs"
+	"github.com/Jeffail/benthos/lib/processor"
+)
+
+//------------------------------------------------------------------------------
+
+// TypeSpec is the internal representation of an output type, holding the
+// metadata used to document, lint and generate example configs for it.
+type TypeSpec struct {
+	Description string
+	FieldSpecs  docs.FieldSpecs
+}
+
+//------------------------------------------------------------------------------
+
+// StdoutConfig contains config fields for the stdout output type.
+type StdoutConfig struct {
+	Delimiter string `json:"delimiter" yaml:"delimiter"`
+}
+
+// NewStdoutConfig creates a new StdoutConfig with default values.
+func NewStdoutConfig() StdoutConfig {
+	return StdoutConfig{Delimiter: ""}
+}
+
+// FileConfig contains config fields for the file output type.
+type FileConfig struct {
+	Path      string `json:"path" yaml:"path"`
+	Delimiter string `json:"delimiter" yaml:"delimiter"`
+}
+
+// NewFileConfig creates a new FileConfig with default values.
+func NewFileConfig() FileConfig {
+	return FileConfig{Path: "", Delimiter: ""}
+}
+
+// KafkaConfig contains config fields for the kafka output type.
+type KafkaConfig struct {
+	Addresses []string `json:"addresses" yaml:"addresses"`
+	Topic     string   `json:"topic" yaml:"topic"`
+	ClientID  string   `json:"client_id" yaml:"client_id"`
+	Key       string   `json:"key" yaml:"key"`
+}
+
+// NewKafkaConfig creates a new KafkaConfig with default values.
+func NewKafkaConfig() KafkaConfig {
+	return KafkaConfig{
+		Addresses: []string{"localhost:9092"},
+		Topic:     "",
+		ClientID:  "benthos",
+		Key:       "",
+	}
+}
+
+// HTTPClientConfig contains config fields for the http_client output type.
+type HTTPClientConfig struct {
+	URL  string `json:"url" yaml:"url"`
+	Verb string `json:"verb" yaml:"verb"`
+}
+
+// NewHTTPClientConfig creates a new HTTPClientConfig with default values.
+func NewHTTPClientConfig() HTTPClientConfig {
+	return HTTPClientConfig{URL: "", Verb: "POST"}
+}
+
+//------------------------------------------------------------------------------
+
+// Config is the all-encompassing configuration struct for all output types,
+// where only the config field relevant to Type is parsed at runtime.
+type Config struct {
+	Type       string             `json:"type" yaml:"type"`
+	Stdout     StdoutConfig       `json:"stdout" yaml:"stdout"`
+	File       FileConfig         `json:"file" yaml:"file"`
+	Kafka      KafkaConfig        `json:"kafka" yaml:"kafka"`
+	HTTPClient HTTPClientConfig   `json:"http_client" yaml:"http_client"`
+	Processors []processor.Config `json:"processors" yaml:"processors"`
+}
+
+// NewConfig returns a new configuration with default values.
+func NewConfig() Config {
+	return Config{
+		Type:       "stdout",
+		Stdout:     NewStdoutConfig(),
+		File:       NewFileConfig(),
+		Kafka:      NewKafkaConfig(),
+		HTTPClient: NewHTTPClientConfig(),
+		Processors: []processor.Config{},
+	}
+}
+
+// SanitiseConfig returns a sanitised version of conf, including only the
+// type field and the sub-config relevant to conf.Type.
+func SanitiseConfig(conf Config) (interface{}, error) {
+	sanitised := map[string]interface{}{
+		"type": conf.Type,
+	}
+
+	switch conf.Type {
+	case "stdout":
+		sanitised["stdout"] = conf.Stdout
+	case "file":
+		sanitised["file"] = conf.File
+	case "kafka":
+		sanitised["kafka"] = conf.Kafka
+	case "http_client":
+		sanitised["http_client"] = conf.HTTPClient
+	}
+
+	if len(conf.Processors) > 0 {
+		procs := make([]interface{}, len(conf.Processors))
+		for i, p := range conf.Processors {
+			sanProc, err := processor.SanitiseConfig(p)
+			if err != nil {
+				return nil, err
+			}
+			procs[i] = sanProc
+		}
+		sanitised["processors"] = procs
+	}
+
+	return sanitised, nil
+}
+
+//------------------------------------------------------------------------------
+
+// Constructors is a registry of all output types, keyed by type name, along
+// with the FieldSpecs used to lint and document their configs.
+var Constructors = map[string]TypeSpec{
+	"stdout": {
+		Description: "Writes messages to stdout.",
+		FieldSpecs: docs.FieldSpecs{
+			{Name: "delimiter", Type: docs.FieldString, Description: "A custom delimiter to separate messages with, defaults to newline."},
+		},
+	},
+	"file": {
+		Description: "Writes messages to a file on disk, one message per line.",
+		FieldSpecs: docs.FieldSpecs{
+			{Name: "path", Type: docs.FieldString, Description: "The path of the file to write to."},
+			{Name: "delimiter", Type: docs.FieldString, Description: "A custom delimiter to separate messages with, defaults to newline."},
+		},
+	},
+	"kafka": {
+		Description: "Writes messages to a Kafka broker topic.",
+		FieldSpecs: docs.FieldSpecs{
+			{Name: "addresses", Type: docs.FieldArray, Description: "A list of broker addresses to connect to."},
+			{Name: "topic", Type: docs.FieldString, Description: "The topic to write to."},
+			{Name: "client_id", Type: docs.FieldString, Description: "An identifier for the client connection."},
+			{Name: "key", Type: docs.FieldString, Description: "An optional key to populate for each message."},
+		},
+	},
+	"http_client": {
+		Description: "Sends messages to an HTTP endpoint.",
+		FieldSpecs: docs.FieldSpecs{
+			{Name: "url", Type: docs.FieldString, Description: "The URL to send messages to."},
+			{Name: "verb", Type: docs.FieldString, Description: "The HTTP verb to use for the request."},
+		},
+	},
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package pipeline holds the configuration type for the Benthos processing
+// pipeline, which is simply an ordered list of processors applied between
+// the input and output layers. Unlike input/output/buffer/processor it has
+// no registry of its own; linting its contents means linting each of its
+// processors against processor.Constructors.
+package pipeline
+
+import "github.com/Jeffail/benthos/lib/processor"
+
+//------------------------------------------------------------------------------
+
+// Config is the configuration struct for the processing pipeline.
+type Config struct {
+	Processors []processor.Config `json:"processors" yaml:"processors"`
+}
+
+// NewConfig returns a new configuration with default values.
+func NewConfig() Config {
+	return Config{
+		Processors: []processor.Config{},
+	}
+}
+
+// SanitiseConfig returns a sanitised version of conf.
+func SanitiseConfig(conf Config) (interface{}, error) {
+	procs := make([]interface{}, len(conf.Processors))
+	for i, p := range conf.Processors {
+		sanProc, err := processor.SanitiseConfig(p)
+		if err != nil {
+			return nil, err
+		}
+		procs[i] = sanProc
+	}
+
+	return map[string]interface{}{
+		"processors": procs,
+	}, nil
+}
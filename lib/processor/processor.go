@@ -0,0 +1,117 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package processor holds the configuration types for Benthos message
+// processors, along with the registry of constructors (Constructors) that
+// the generator and linter use to discover the set of supported types and
+// their field specs.
+package processor
+
+import "github.com/Jeffail/benthos/lib/config/docs"
+
+//------------------------------------------------------------------------------
+
+// TypeSpec is the internal representation of a processor type, holding the
+// metadata used to document, lint and generate example configs for it.
+type TypeSpec struct {
+	Description string
+	FieldSpecs  docs.FieldSpecs
+}
+
+//------------------------------------------------------------------------------
+
+// JSONConfig contains config fields for the json processor type.
+type JSONConfig struct {
+	Operator string `json:"operator" yaml:"operator"`
+	Path     string `json:"path" yaml:"path"`
+}
+
+// NewJSONConfig creates a new JSONConfig with default values.
+func NewJSONConfig() JSONConfig {
+	return JSONConfig{Operator: "set", Path: ""}
+}
+
+// TextConfig contains config fields for the text processor type.
+type TextConfig struct {
+	Operator string `json:"operator" yaml:"operator"`
+	Arg      string `json:"arg" yaml:"arg"`
+}
+
+// NewTextConfig creates a new TextConfig with default values.
+func NewTextConfig() TextConfig {
+	return TextConfig{Operator: "trim_space", Arg: ""}
+}
+
+//------------------------------------------------------------------------------
+
+// Config is the all-encompassing configuration struct for all processor
+// types, where only the config field relevant to Type is parsed at runtime.
+type Config struct {
+	Type string     `json:"type" yaml:"type"`
+	JSON JSONConfig `json:"json" yaml:"json"`
+	Text TextConfig `json:"text" yaml:"text"`
+}
+
+// NewConfig returns a new configuration with default values.
+func NewConfig() Config {
+	return Config{
+		Type: "json",
+		JSON: NewJSONConfig(),
+		Text: NewTextConfig(),
+	}
+}
+
+// SanitiseConfig returns a sanitised version of conf, including only the
+// type field and the sub-config relevant to conf.Type.
+func SanitiseConfig(conf Config) (interface{}, error) {
+	sanitised := map[string]interface{}{
+		"type": conf.Type,
+	}
+
+	switch conf.Type {
+	case "json":
+		sanitised["json"] = conf.JSON
+	case "text":
+		sanitised["text"] = conf.Text
+	}
+
+	return sanitised, nil
+}
+
+//------------------------------------------------------------------------------
+
+// Constructors is a registry of all processor types, keyed by type name,
+// along with the FieldSpecs used to lint and document their configs.
+var Constructors = map[string]TypeSpec{
+	"json": {
+		Description: "Performs a mutation on a JSON document within the message.",
+		FieldSpecs: docs.FieldSpecs{
+			{Name: "operator", Type: docs.FieldString, Description: "The JSON operation to perform."},
+			{Name: "path", Type: docs.FieldString, Description: "A dot path identifying the target field."},
+		},
+	},
+	"text": {
+		Description: "Performs a mutation on the raw text of a message.",
+		FieldSpecs: docs.FieldSpecs{
+			{Name: "operator", Type: docs.FieldString, Description: "The text operation to perform."},
+			{Name: "arg", Type: docs.FieldString, Description: "An argument for the chosen operator, when applicable."},
+		},
+	},
+}
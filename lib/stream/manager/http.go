@@ -0,0 +1,112 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package manager
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Jeffail/benthos/lib/stream"
+)
+
+//------------------------------------------------------------------------------
+
+// StreamsUpdateTimeout is the duration the manager waits for a stream to
+// stop gracefully when it is being updated or deleted via the HTTP API.
+const StreamsUpdateTimeout = time.Second * 20
+
+// RegisterEndpoints wires a "/streams" endpoint onto mux that lists, adds,
+// updates and removes streams managed by Type. The API used is:
+//
+// GET /streams         - list the IDs of all running streams.
+// GET /streams/{id}    - fetch the config of a running stream.
+// PUT /streams/{id}    - create (if absent) or update (if present) a stream
+//                         using the JSON stream.Config in the request body.
+// DELETE /streams/{id} - stop and remove a running stream.
+//
+// This only registers the mux handlers; mounting that mux on a listening
+// *http.Server as part of the main service's API (alongside its other
+// endpoints) is left to the service entrypoint, which isn't part of this
+// tool-focused chunk of the repo.
+func (m *Type) RegisterEndpoints(mux *http.ServeMux) {
+	mux.HandleFunc("/streams", m.handleStreams)
+	mux.HandleFunc("/streams/", m.handleStream)
+}
+
+func (m *Type) handleStreams(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(m.List()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (m *Type) handleStream(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/streams/")
+	if len(id) == 0 {
+		http.Error(w, "missing stream id", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		conf, exists := m.Read(id)
+		if !exists {
+			http.Error(w, "stream not found", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(conf); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	case http.MethodPut:
+		var conf stream.Config
+		if err := json.NewDecoder(r.Body).Decode(&conf); err != nil {
+			http.Error(w, "invalid stream config: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if _, exists := m.Read(id); exists {
+			if err := m.Update(id, conf, StreamsUpdateTimeout); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		} else if err := m.Create(id, conf); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		if err := m.Delete(id, StreamsUpdateTimeout); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
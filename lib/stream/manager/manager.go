@@ -0,0 +1,157 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package manager provides a runtime registry of named streams, allowing
+// many stream pipelines to be run within a single process and managed
+// (listed, added, updated, removed) via its HTTP endpoints.
+package manager
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Jeffail/benthos/lib/stream"
+)
+
+//------------------------------------------------------------------------------
+
+// Streamer is the minimal interface a running stream must satisfy in order
+// to be tracked by Type.
+type Streamer interface {
+	Stop(timeout time.Duration) error
+}
+
+// Constructor builds and starts a running Streamer from a stream.Config.
+type Constructor func(id string, conf stream.Config) (Streamer, error)
+
+//------------------------------------------------------------------------------
+
+type wrappedStream struct {
+	conf   stream.Config
+	stream Streamer
+}
+
+// Type manages a live collection of streams, keyed by an arbitrary ID,
+// allowing them to be listed, created, updated and removed at runtime.
+type Type struct {
+	constructor Constructor
+
+	mut     sync.Mutex
+	streams map[string]wrappedStream
+}
+
+// New returns a new stream manager that uses constructor to spin up new
+// stream instances.
+func New(constructor Constructor) *Type {
+	return &Type{
+		constructor: constructor,
+		streams:     map[string]wrappedStream{},
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// List returns the IDs of all currently running streams.
+func (m *Type) List() []string {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	ids := make([]string, 0, len(m.streams))
+	for id := range m.streams {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Read returns the config of a currently running stream.
+func (m *Type) Read(id string) (stream.Config, bool) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	s, exists := m.streams[id]
+	return s.conf, exists
+}
+
+// Create starts a new stream with id and conf. An error is returned if a
+// stream with the same id is already running.
+func (m *Type) Create(id string, conf stream.Config) error {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	if _, exists := m.streams[id]; exists {
+		return fmt.Errorf("stream already exists: %v", id)
+	}
+
+	s, err := m.constructor(id, conf)
+	if err != nil {
+		return fmt.Errorf("failed to create stream '%v': %v", id, err)
+	}
+
+	m.streams[id] = wrappedStream{conf: conf, stream: s}
+	return nil
+}
+
+// Update stops and replaces an existing stream with a new config. An error
+// is returned if the stream does not exist. If the stream stops successfully
+// but the replacement fails to start, the stream is removed from the
+// registry rather than left behind as a stopped entry reported as live; the
+// returned error makes clear the stream is now down and must be recreated.
+func (m *Type) Update(id string, conf stream.Config, timeout time.Duration) error {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	existing, exists := m.streams[id]
+	if !exists {
+		return fmt.Errorf("stream does not exist: %v", id)
+	}
+
+	if err := existing.stream.Stop(timeout); err != nil {
+		return fmt.Errorf("failed to stop stream '%v' for update: %v", id, err)
+	}
+
+	s, err := m.constructor(id, conf)
+	if err != nil {
+		delete(m.streams, id)
+		return fmt.Errorf("stream '%v' was stopped but failed to restart with new config, it is no longer running: %v", id, err)
+	}
+
+	m.streams[id] = wrappedStream{conf: conf, stream: s}
+	return nil
+}
+
+// Delete stops and removes a stream. An error is returned if the stream does
+// not exist.
+func (m *Type) Delete(id string, timeout time.Duration) error {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	existing, exists := m.streams[id]
+	if !exists {
+		return fmt.Errorf("stream does not exist: %v", id)
+	}
+
+	if err := existing.stream.Stop(timeout); err != nil {
+		return fmt.Errorf("failed to stop stream '%v': %v", id, err)
+	}
+
+	delete(m.streams, id)
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package manager
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Jeffail/benthos/lib/stream"
+)
+
+type fakeStream struct {
+	stopped bool
+}
+
+func (f *fakeStream) Stop(timeout time.Duration) error {
+	f.stopped = true
+	return nil
+}
+
+func fakeConstructor(id string, conf stream.Config) (Streamer, error) {
+	return &fakeStream{}, nil
+}
+
+func failingConstructor(id string, conf stream.Config) (Streamer, error) {
+	return nil, errors.New("nope")
+}
+
+func TestManagerCRUD(t *testing.T) {
+	m := New(fakeConstructor)
+
+	if err := m.Create("foo", stream.NewConfig()); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Create("foo", stream.NewConfig()); err == nil {
+		t.Error("expected error creating duplicate stream")
+	}
+
+	if ids := m.List(); len(ids) != 1 || ids[0] != "foo" {
+		t.Errorf("unexpected ids: %v", ids)
+	}
+
+	if _, exists := m.Read("bar"); exists {
+		t.Error("expected bar to not exist")
+	}
+
+	if err := m.Update("foo", stream.NewConfig(), time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Delete("foo", time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Delete("foo", time.Second); err == nil {
+		t.Error("expected error deleting missing stream")
+	}
+}
+
+func TestManagerUpdateFailureRemovesDeadStream(t *testing.T) {
+	original := &fakeStream{}
+	m := &Type{
+		constructor: fakeConstructor,
+		streams:     map[string]wrappedStream{"foo": {conf: stream.NewConfig(), stream: original}},
+	}
+	m.constructor = failingConstructor
+
+	if err := m.Update("foo", stream.NewConfig(), time.Second); err == nil {
+		t.Fatal("expected an error from the failing constructor")
+	}
+	if !original.stopped {
+		t.Error("expected the original stream to have been stopped")
+	}
+	if _, exists := m.Read("foo"); exists {
+		t.Error("expected the stream to be removed rather than left registered as live")
+	}
+	if ids := m.List(); len(ids) != 0 {
+		t.Errorf("expected no streams to remain, got: %v", ids)
+	}
+}
+
+func TestManagerHTTPEndpoints(t *testing.T) {
+	m := New(fakeConstructor)
+
+	mux := http.NewServeMux()
+	m.RegisterEndpoints(mux)
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	confBytes, err := json.Marshal(stream.NewConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := http.NewRequest(http.MethodPut, server.URL+"/streams/foo", bytes.NewReader(confBytes))
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status creating stream: %v", res.StatusCode)
+	}
+
+	res, err = http.Get(server.URL + "/streams")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	if err = json.NewDecoder(res.Body).Decode(&ids); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] != "foo" {
+		t.Fatalf("unexpected ids: %v", ids)
+	}
+
+	req, _ = http.NewRequest(http.MethodDelete, server.URL+"/streams/foo", nil)
+	res, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status deleting stream: %v", res.StatusCode)
+	}
+}
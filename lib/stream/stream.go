@@ -0,0 +1,52 @@
+// Copyright (c) 2018 Ashley Jeffs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package stream holds the configuration for a single Benthos stream: an
+// input feeding a buffer feeding a processing pipeline feeding an output.
+// This is the unit lib/stream/manager.Type tracks and runs many of under a
+// single process.
+package stream
+
+import (
+	"github.com/Jeffail/benthos/lib/buffer"
+	"github.com/Jeffail/benthos/lib/input"
+	"github.com/Jeffail/benthos/lib/output"
+	"github.com/Jeffail/benthos/lib/pipeline"
+)
+
+//------------------------------------------------------------------------------
+
+// Config is the configuration struct for a single stream.
+type Config struct {
+	Input    input.Config    `json:"input" yaml:"input"`
+	Buffer   buffer.Config   `json:"buffer" yaml:"buffer"`
+	Pipeline pipeline.Config `json:"pipeline" yaml:"pipeline"`
+	Output   output.Config   `json:"output" yaml:"output"`
+}
+
+// NewConfig returns a new configuration with default values.
+func NewConfig() Config {
+	return Config{
+		Input:    input.NewConfig(),
+		Buffer:   buffer.NewConfig(),
+		Pipeline: pipeline.NewConfig(),
+		Output:   output.NewConfig(),
+	}
+}